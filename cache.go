@@ -18,28 +18,51 @@ type cache struct {
 }
 
 func (c *cache) add(key string, value ByteView) {
+	c.addWithExpire(key, value, time.Time{})
+}
+
+// addWithExpire adds a value that expires at expire. A zero expire falls
+// back to the cache's configured ttl, so a peer's remaining TTL can be
+// honored instead of resetting the local TTL clock.
+func (c *cache) addWithExpire(key string, value ByteView, expire time.Time) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.lru == nil {
 		c.lru = lru.New(c.cacheBytes, nil)
 	}
-	var expire time.Time
-	if c.ttl != 0 {
+	if expire.IsZero() && c.ttl != 0 {
 		expire = time.Now().Add(c.ttl)
 	}
 	c.lru.Add(key, value, expire)
 }
 
 func (c *cache) get(key string) (value ByteView, ok bool) {
+	value, _, ok = c.getWithExpire(key)
+	return
+}
+
+// getWithExpire returns the cached value alongside its absolute
+// expiration time (the zero value if it never expires).
+func (c *cache) getWithExpire(key string) (value ByteView, expire time.Time, ok bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.lru == nil {
 		return
 	}
 
-	if v, ok := c.lru.Get(key); ok {
-		return v.(ByteView), ok
+	if v, exp, ok := c.lru.GetWithExpire(key); ok {
+		return v.(ByteView), exp, ok
 	}
 
 	return
 }
+
+// remove evicts key from the cache, if present.
+func (c *cache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lru == nil {
+		return
+	}
+	c.lru.Remove(key)
+}
@@ -3,6 +3,9 @@ package yocache
 import (
 	"bytes"
 	"compress/gzip"
+	"time"
+
+	"yocache/yocachepb"
 )
 
 type Encoder interface {
@@ -28,6 +31,79 @@ type Codec interface {
 	Decoder
 }
 
+// ViewMeta carries metadata about a ByteView that travels alongside the
+// payload on the wire, for Codecs that support it.
+type ViewMeta struct {
+	// Expire is the absolute time at which the value is considered
+	// stale on the owning peer; the zero value means it never expires.
+	Expire time.Time
+
+	// MinuteQPS is a hint of how frequently this key was requested, in
+	// requests per minute, as observed by the owning peer. See
+	// Group.MinuteQPS.
+	MinuteQPS float64
+
+	// ContentEncoding names how the payload bytes are encoded, e.g.
+	// "identity" or "gzip". Every value cached by this package is stored
+	// decoded, so this is currently always "identity"; the field exists
+	// so a future Getter that hands back pre-compressed bytes doesn't
+	// need a wire format change.
+	ContentEncoding string
+}
+
+// ContentEncodingIdentity is the only ContentEncoding value this package
+// currently produces, since every cached value is stored decoded.
+// PeerGetter server implementations (HTTPPool.ServeHTTP,
+// grpcpool.Server.Get) use it to populate ViewMeta.ContentEncoding.
+const ContentEncodingIdentity = "identity"
+
+// MetaEncoder is implemented by Codecs that can embed a ViewMeta
+// alongside the payload, such as ProtoCodec.
+type MetaEncoder interface {
+	EncodeWithMeta(view ByteView, meta ViewMeta) ([]byte, error)
+}
+
+// MetaDecoder is implemented by Codecs that can recover a ViewMeta
+// alongside the payload, such as ProtoCodec.
+type MetaDecoder interface {
+	DecodeWithMeta(body []byte) (ByteView, ViewMeta, error)
+}
+
+// content types used to negotiate a Codec between HTTPPool peers via the
+// Accept/Content-Type headers.
+const (
+	contentTypeRaw   = "application/octet-stream"
+	contentTypeGzip  = "application/x-yocache-gzip"
+	contentTypeProto = "application/x-yocache-proto"
+)
+
+// codecForContentType maps a negotiated Content-Type/Accept header value
+// to the Codec that understands it, defaulting to RawCodec for unknown
+// or empty values so older peers keep interoperating.
+func codecForContentType(contentType string) Codec {
+	switch contentType {
+	case contentTypeProto:
+		return ProtoCodec{}
+	case contentTypeGzip:
+		return GzipCodec{}
+	default:
+		return RawCodec{}
+	}
+}
+
+// contentTypeForCodec is the inverse of codecForContentType, used to set
+// the Accept/Content-Type header for a given Codec.
+func contentTypeForCodec(c Codec) string {
+	switch c.(type) {
+	case ProtoCodec:
+		return contentTypeProto
+	case GzipCodec:
+		return contentTypeGzip
+	default:
+		return contentTypeRaw
+	}
+}
+
 type RawCodec struct {}
 var _ Codec = (*RawCodec)(nil)
 
@@ -76,4 +152,54 @@ func (c GzipCodec) Decode(body []byte) (ByteView, error) {
 	}
 
 	return ByteView{b: res.Bytes()}, nil
+}
+
+// ProtoCodec wraps the payload in a yocachepb.Envelope carrying
+// expiration, a minute-qps hint, and a content-encoding tag, mirroring
+// the groupcache wire model. It negotiates over the
+// application/x-yocache-proto content type so mixed-codec deployments
+// can interoperate.
+type ProtoCodec struct{}
+
+var _ Codec = ProtoCodec{}
+var _ MetaEncoder = ProtoCodec{}
+var _ MetaDecoder = ProtoCodec{}
+
+func (c ProtoCodec) Encode(view ByteView) ([]byte, error) {
+	return c.EncodeWithMeta(view, ViewMeta{})
+}
+
+// EncodeWithMeta marshals view and meta into a yocachepb.Envelope.
+func (c ProtoCodec) EncodeWithMeta(view ByteView, meta ViewMeta) ([]byte, error) {
+	env := &yocachepb.Envelope{
+		Value:           view.ByteSlice(),
+		MinuteQps:       meta.MinuteQPS,
+		ContentEncoding: meta.ContentEncoding,
+	}
+	if !meta.Expire.IsZero() {
+		env.ExpireUnixNano = meta.Expire.UnixNano()
+	}
+	return env.Marshal()
+}
+
+func (c ProtoCodec) Decode(body []byte) (ByteView, error) {
+	view, _, err := c.DecodeWithMeta(body)
+	return view, err
+}
+
+// DecodeWithMeta unmarshals a yocachepb.Envelope, recovering the
+// payload alongside its ViewMeta.
+func (c ProtoCodec) DecodeWithMeta(body []byte) (ByteView, ViewMeta, error) {
+	var env yocachepb.Envelope
+	if err := env.Unmarshal(body); err != nil {
+		return ByteView{}, ViewMeta{}, err
+	}
+	meta := ViewMeta{
+		MinuteQPS:       env.MinuteQps,
+		ContentEncoding: env.ContentEncoding,
+	}
+	if env.ExpireUnixNano != 0 {
+		meta.Expire = time.Unix(0, env.ExpireUnixNano)
+	}
+	return ByteView{b: env.Value}, meta, nil
 }
\ No newline at end of file
@@ -0,0 +1,42 @@
+package grpcpool
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+)
+
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type unmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+// wireCodec implements grpc's encoding.Codec using each message's own
+// hand-rolled Marshal/Unmarshal, since the messages in this package are
+// not generated against google.golang.org/protobuf's reflection API.
+type wireCodec struct{}
+
+func (wireCodec) Name() string { return "yocache" }
+
+func (wireCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(marshaler)
+	if !ok {
+		return nil, fmt.Errorf("grpcpool: %T does not implement Marshal", v)
+	}
+	return m.Marshal()
+}
+
+func (wireCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(unmarshaler)
+	if !ok {
+		return fmt.Errorf("grpcpool: %T does not implement Unmarshal", v)
+	}
+	return m.Unmarshal(data)
+}
+
+func init() {
+	encoding.RegisterCodec(wireCodec{})
+}
@@ -0,0 +1,69 @@
+package grpcpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"yocache"
+)
+
+// grpcGetter implements yocache.PeerGetter over a persistent gRPC
+// connection to a single peer.
+type grpcGetter struct {
+	addr   string
+	codec  yocache.Codec
+	conn   *grpc.ClientConn
+	client YoCacheClient
+}
+
+func newGRPCGetter(addr string, opts GRPCPoolOptions) (*grpcGetter, error) {
+	conn, err := grpc.Dial(addr, defaultDialOptions(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcGetter{
+		addr:   addr,
+		codec:  opts.Codec,
+		conn:   conn,
+		client: NewYoCacheClient(conn),
+	}, nil
+}
+
+// Get fetches group/key from the peer over the persistent connection.
+func (g *grpcGetter) Get(ctx context.Context, group string, key string) ([]byte, time.Time, error) {
+	resp, err := g.client.Get(ctx, &GetRequest{Group: group, Key: key})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var (
+		view yocache.ByteView
+		meta yocache.ViewMeta
+	)
+	if md, ok := g.codec.(yocache.MetaDecoder); ok {
+		view, meta, err = md.DecodeWithMeta(resp.Value)
+	} else {
+		view, err = g.codec.Decode(resp.Value)
+	}
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("decoding response payload: %v", err)
+	}
+
+	return view.ByteSlice(), meta.Expire, nil
+}
+
+// Remove asks the peer to evict group/key from its local caches.
+func (g *grpcGetter) Remove(group string, key string) error {
+	_, err := g.client.Remove(context.Background(), &RemoveRequest{Group: group, Key: key})
+	return err
+}
+
+// Close tears down the persistent connection to this peer.
+func (g *grpcGetter) Close() error {
+	return g.conn.Close()
+}
+
+var _ yocache.PeerGetter = (*grpcGetter)(nil)
@@ -0,0 +1,71 @@
+package grpcpool
+
+import (
+	"context"
+	"fmt"
+
+	"yocache"
+)
+
+// Server implements YoCacheServer, serving local yocache.Group data to
+// gRPC peers. Register it on a *grpc.Server with RegisterYoCacheServer.
+type Server struct {
+	UnimplementedYoCacheServer
+
+	// Codec specifies the encoding mechanism applied to the payload
+	// bytes inside GetResponse. If nil, yocache.RawCodec is used.
+	Codec yocache.Codec
+}
+
+func (s *Server) codec() yocache.Codec {
+	if s.Codec == nil {
+		return yocache.RawCodec{}
+	}
+	return s.Codec
+}
+
+// Get implements YoCacheServer.
+func (s *Server) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	group := yocache.GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("no such group: %s", req.Group)
+	}
+
+	view, err := group.Get(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if me, ok := s.codec().(yocache.MetaEncoder); ok {
+		expire, _ := group.Expire(req.Key)
+		body, err = me.EncodeWithMeta(view, yocache.ViewMeta{
+			Expire:          expire,
+			MinuteQPS:       group.MinuteQPS(req.Key),
+			ContentEncoding: yocache.ContentEncodingIdentity,
+		})
+	} else {
+		body, err = s.codec().Encode(view)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetResponse{Value: body}, nil
+}
+
+// Remove implements YoCacheServer. It evicts the key from this peer's
+// local caches only, mirroring HTTPPool's DELETE handler; the caller is
+// the one fanning Remove out across all peers, so re-forwarding here
+// would recurse.
+func (s *Server) Remove(ctx context.Context, req *RemoveRequest) (*RemoveResponse, error) {
+	group := yocache.GetGroup(req.Group)
+	if group == nil {
+		return nil, fmt.Errorf("no such group: %s", req.Group)
+	}
+
+	group.LocalRemove(req.Key)
+	return &RemoveResponse{}, nil
+}
+
+var _ YoCacheServer = (*Server)(nil)
@@ -0,0 +1,184 @@
+// Hand-written wire encoding for the messages declared in yocache.proto.
+// There is no protoc step wired into this repo, so this file is NOT
+// generated — it implements the protobuf wire format by hand (see
+// forEachField/appendVarint below) and must be kept in sync with
+// yocache.proto manually. Do not regenerate it with protoc-gen-go; that
+// would discard the hand-rolled encoding logic.
+
+package grpcpool
+
+import "fmt"
+
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// GetRequest is the request message for YoCache.Get.
+type GetRequest struct {
+	Group string
+	Key   string
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GetRequest) ProtoMessage()    {}
+
+func (m *GetRequest) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendString(buf, 1, m.Group)
+	buf = appendString(buf, 2, m.Key)
+	return buf, nil
+}
+
+func (m *GetRequest) Unmarshal(data []byte) error {
+	*m = GetRequest{}
+	return forEachField(data, func(field int, v []byte) {
+		switch field {
+		case 1:
+			m.Group = string(v)
+		case 2:
+			m.Key = string(v)
+		}
+	})
+}
+
+// GetResponse is the response message for YoCache.Get. Value holds the
+// codec-encoded payload bytes; the yocache.Codec interface applies to
+// it so gzip/proto/raw remain composable on top of this transport.
+type GetResponse struct {
+	Value []byte
+}
+
+func (m *GetResponse) Reset()         { *m = GetResponse{} }
+func (m *GetResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GetResponse) ProtoMessage()    {}
+
+func (m *GetResponse) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendBytes(buf, 1, m.Value)
+	return buf, nil
+}
+
+func (m *GetResponse) Unmarshal(data []byte) error {
+	*m = GetResponse{}
+	return forEachField(data, func(field int, v []byte) {
+		if field == 1 {
+			m.Value = append([]byte(nil), v...)
+		}
+	})
+}
+
+// RemoveRequest is the request message for YoCache.Remove.
+type RemoveRequest struct {
+	Group string
+	Key   string
+}
+
+func (m *RemoveRequest) Reset()         { *m = RemoveRequest{} }
+func (m *RemoveRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RemoveRequest) ProtoMessage()    {}
+
+func (m *RemoveRequest) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = appendString(buf, 1, m.Group)
+	buf = appendString(buf, 2, m.Key)
+	return buf, nil
+}
+
+func (m *RemoveRequest) Unmarshal(data []byte) error {
+	*m = RemoveRequest{}
+	return forEachField(data, func(field int, v []byte) {
+		switch field {
+		case 1:
+			m.Group = string(v)
+		case 2:
+			m.Key = string(v)
+		}
+	})
+}
+
+// RemoveResponse is the (empty) response message for YoCache.Remove.
+type RemoveResponse struct{}
+
+func (m *RemoveResponse) Reset()         { *m = RemoveResponse{} }
+func (m *RemoveResponse) String() string { return "RemoveResponse{}" }
+func (*RemoveResponse) ProtoMessage()    {}
+
+func (m *RemoveResponse) Marshal() ([]byte, error)    { return nil, nil }
+func (m *RemoveResponse) Unmarshal(data []byte) error { return nil }
+
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func appendBytes(buf []byte, field int, v []byte) []byte {
+	if len(v) == 0 {
+		return buf
+	}
+	buf = appendVarint(buf, uint64(field)<<3|wireBytes)
+	buf = appendVarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func appendString(buf []byte, field int, v string) []byte {
+	return appendBytes(buf, field, []byte(v))
+}
+
+func consumeVarint(data []byte) (v uint64, n int, err error) {
+	for shift := uint(0); ; shift += 7 {
+		if n >= len(data) {
+			return 0, 0, fmt.Errorf("grpcpool: truncated varint")
+		}
+		b := data[n]
+		n++
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, n, nil
+		}
+		if shift >= 63 {
+			return 0, 0, fmt.Errorf("grpcpool: varint overflow")
+		}
+	}
+}
+
+// forEachField walks data's length-delimited fields, calling fn with
+// each field number and its raw value. Varint fields are not used by
+// any message in this package, so only wireBytes is decoded.
+func forEachField(data []byte, fn func(field int, value []byte)) error {
+	for len(data) > 0 {
+		tag, n, err := consumeVarint(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+		field, wireType := int(tag>>3), tag&0x7
+
+		switch wireType {
+		case wireBytes:
+			length, n, err := consumeVarint(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+			if uint64(len(data)) < length {
+				return fmt.Errorf("grpcpool: truncated length-delimited field %d", field)
+			}
+			fn(field, data[:length])
+			data = data[length:]
+		case wireVarint:
+			_, n, err := consumeVarint(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+		default:
+			return fmt.Errorf("grpcpool: unsupported wire type %d for field %d", wireType, field)
+		}
+	}
+	return nil
+}
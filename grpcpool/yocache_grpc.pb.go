@@ -0,0 +1,115 @@
+// Hand-written client/server scaffolding for the YoCache gRPC service
+// declared in yocache.proto, mirroring protoc-gen-go-grpc's usual output.
+// There is no protoc step wired into this repo, so this file is NOT
+// generated; keep it in sync with yocache.proto manually, and do not
+// regenerate it with protoc-gen-go-grpc.
+
+package grpcpool
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	YoCache_Get_FullMethodName    = "/grpcpool.YoCache/Get"
+	YoCache_Remove_FullMethodName = "/grpcpool.YoCache/Remove"
+)
+
+// YoCacheClient is the client API for the YoCache service.
+type YoCacheClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error)
+}
+
+type yoCacheClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewYoCacheClient returns a YoCacheClient backed by cc.
+func NewYoCacheClient(cc grpc.ClientConnInterface) YoCacheClient {
+	return &yoCacheClient{cc}
+}
+
+func (c *yoCacheClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	if err := c.cc.Invoke(ctx, YoCache_Get_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *yoCacheClient) Remove(ctx context.Context, in *RemoveRequest, opts ...grpc.CallOption) (*RemoveResponse, error) {
+	out := new(RemoveResponse)
+	if err := c.cc.Invoke(ctx, YoCache_Remove_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// YoCacheServer is the server API for the YoCache service.
+type YoCacheServer interface {
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Remove(context.Context, *RemoveRequest) (*RemoveResponse, error)
+}
+
+// UnimplementedYoCacheServer may be embedded for forward compatibility.
+type UnimplementedYoCacheServer struct{}
+
+func (UnimplementedYoCacheServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+
+func (UnimplementedYoCacheServer) Remove(context.Context, *RemoveRequest) (*RemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+
+// RegisterYoCacheServer registers srv with s.
+func RegisterYoCacheServer(s grpc.ServiceRegistrar, srv YoCacheServer) {
+	s.RegisterService(&YoCache_ServiceDesc, srv)
+}
+
+func _YoCache_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YoCacheServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: YoCache_Get_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YoCacheServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _YoCache_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YoCacheServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: YoCache_Remove_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YoCacheServer).Remove(ctx, req.(*RemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// YoCache_ServiceDesc is the grpc.ServiceDesc for the YoCache service.
+var YoCache_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcpool.YoCache",
+	HandlerType: (*YoCacheServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Get", Handler: _YoCache_Get_Handler},
+		{MethodName: "Remove", Handler: _YoCache_Remove_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "grpcpool/yocache.proto",
+}
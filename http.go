@@ -1,6 +1,8 @@
 package yocache
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -8,12 +10,23 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 	"yocache/consistenthash"
 )
 
 const (
 	defaultBasePath = "/_yocache/"
 	defaultReplicas = 50
+
+	// statsPathSuffix, appended to BasePath, serves a group's Stats as
+	// JSON: GET <basepath>_stats/<groupname>.
+	statsPathSuffix = "_stats/"
+
+	// defaultDebounce is how long Watch waits for a Registry to go quiet
+	// before rebuilding the ring, coalescing bursts of membership churn
+	// (e.g. a rolling deploy) into a single rebuild.
+	defaultDebounce = 2 * time.Second
 )
 
 // HTTPPool implements PeerPicker and http.Handler for a pool of HTTP peers.
@@ -23,9 +36,10 @@ type HTTPPool struct {
 	// opts specifies the options.
 	opts HTTPPoolOptions
 
-	mu          sync.Mutex // guards peers and httpGetters
+	mu          sync.Mutex // guards peers, httpGetters and peerSet
 	peers       *consistenthash.Map
 	httpGetters map[string]*httpGetter // keyed by e.g. "http://10.0.0.2:8008"
+	peerSet     []string               // last snapshot applied, for diffing on the next one
 }
 
 // NewHTTPPool initializes an HTTP pool of peers.
@@ -63,6 +77,27 @@ type HTTPPoolOptions struct {
 	// Codec specifies the encoding and decoding mechanisms for internal HTTP connections.
 	// If blank, it defaults to RawCodec.
 	Codec Codec
+
+	// Transport, if non-nil, is called per-request to obtain the
+	// http.RoundTripper used to fetch keys from peers, keyed off the
+	// request's context. This allows injecting tracing, auth headers,
+	// custom TLS, or connection pools. If nil, or it returns nil,
+	// http.DefaultTransport is used.
+	Transport func(context.Context) http.RoundTripper
+
+	// Context, if non-nil, builds the context.Context used to serve an
+	// inbound peer request, allowing a deadline or values from r to be
+	// propagated into the local Group.Get / peer fan-out. If nil,
+	// r.Context() is used.
+	Context func(*http.Request) context.Context
+
+	// Registry, if non-nil, lets Watch keep the pool's peer set current
+	// automatically instead of requiring manual Set calls. See Watch.
+	Registry Registry
+
+	// Debounce is how long Watch waits for Registry to stop sending
+	// updates before rebuilding the ring. If zero, it defaults to 2s.
+	Debounce time.Duration
 }
 
 // Log info with server name
@@ -76,8 +111,15 @@ func (p *HTTPPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		panic("HTTPPool serving unexpected path: " + r.URL.Path)
 	}
 	p.Log("%s %s", r.Method, r.URL.Path)
+
+	rest := r.URL.Path[len(p.opts.BasePath):]
+	if strings.HasPrefix(rest, statsPathSuffix) {
+		p.serveStats(w, strings.TrimPrefix(rest, statsPathSuffix))
+		return
+	}
+
 	// /<basepath>/<groupname>/<key> required
-	parts := strings.SplitN(r.URL.Path[len(p.opts.BasePath):], "/", 2)
+	parts := strings.SplitN(rest, "/", 2)
 	if len(parts) != 2 {
 		http.Error(w, "bad request", http.StatusBadRequest)
 		return
@@ -92,38 +134,202 @@ func (p *HTTPPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	view, err := group.Get(key)
+	atomic.AddInt64(&group.stats.ServerRequests, 1)
+
+	if r.Method == http.MethodDelete {
+		group.localRemove(key)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := r.Context()
+	if p.opts.Context != nil {
+		ctx = p.opts.Context(r)
+	}
+
+	view, err := group.Get(ctx, key)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Write the value to the response body as a proto message.
-	body, err := p.opts.Codec.Encode(view)
+	// Negotiate the response codec off the Accept header so mixed-codec
+	// deployments interoperate. Without an Accept header the pool's
+	// configured Codec is used; an unrecognised Accept value gets RawCodec.
+	codec := p.opts.Codec
+	if accept := r.Header.Get("Accept"); accept != "" {
+		codec = codecForContentType(accept)
+	}
+
+	var body []byte
+	if me, ok := codec.(MetaEncoder); ok {
+		_, expire, _ := group.mainCache.getWithExpire(key)
+		body, err = me.EncodeWithMeta(view, ViewMeta{
+			Expire:          expire,
+			MinuteQPS:       group.MinuteQPS(key),
+			ContentEncoding: ContentEncodingIdentity,
+		})
+	} else {
+		body, err = codec.Encode(view)
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Type", contentTypeForCodec(codec))
 	w.Write(body)
 }
 
+// serveStats writes groupName's Stats as JSON, for the optional
+// <basepath>_stats/<groupname> endpoint.
+func (p *HTTPPool) serveStats(w http.ResponseWriter, groupName string) {
+	group := GetGroup(groupName)
+	if group == nil {
+		http.Error(w, "no such group: "+groupName, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(group.Stats()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // Set updates the pool's list of peers.
 // Each value in peers should be a valid base URL,
 // for example "http://example.net:8000".
 func (p *HTTPPool) Set(peers ...string) {
+	p.applyPeers(peers)
+}
+
+// Watch starts keeping the pool's peer set current from opts.Registry
+// instead of requiring manual Set calls. It blocks until the first
+// snapshot is obtained and applied, returning any error from doing so;
+// an empty first snapshot is logged and not applied. It returns
+// immediately with a nil error if opts.Registry is nil.
+// Further snapshots are applied in the background, debounced by
+// opts.Debounce, until ctx is done.
+func (p *HTTPPool) Watch(ctx context.Context) error {
+	if p.opts.Registry == nil {
+		return nil
+	}
+	updates, err := p.opts.Registry.Watch(ctx)
+	if err != nil {
+		return fmt.Errorf("watching peer registry: %w", err)
+	}
+
+	first, ok := <-updates
+	if !ok {
+		return fmt.Errorf("peer registry closed before sending an initial peer list")
+	}
+	if len(first) == 0 {
+		p.Log("peer registry returned no peers for the initial snapshot, ignoring")
+	} else {
+		p.applyPeers(first)
+	}
+
+	go p.debounceUpdates(ctx, updates)
+	return nil
+}
+
+// debounceUpdates applies each snapshot received from updates, holding
+// back a burst of rapid-fire changes until the registry goes quiet for
+// opts.Debounce so a rolling deploy causes one ring rebuild instead of
+// one per peer. A snapshot with no peers at all is assumed to be a
+// transient registry hiccup rather than a real "no peers" state, and is
+// ignored so it can't wipe out an otherwise healthy ring.
+func (p *HTTPPool) debounceUpdates(ctx context.Context, updates <-chan []string) {
+	debounce := p.opts.Debounce
+	if debounce == 0 {
+		debounce = defaultDebounce
+	}
+
+	var (
+		timer      *time.Timer
+		pending    []string
+		pendingSet bool
+	)
+	for {
+		var fire <-chan time.Time
+		if timer != nil {
+			fire = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case peers, ok := <-updates:
+			if !ok {
+				return
+			}
+			if len(peers) == 0 {
+				p.Log("peer registry returned no peers, ignoring update")
+				continue
+			}
+			pending, pendingSet = peers, true
+			if timer == nil {
+				timer = time.NewTimer(debounce)
+			} else {
+				timer.Reset(debounce)
+			}
+		case <-fire:
+			if pendingSet {
+				p.applyPeers(pending)
+				pendingSet = false
+			}
+			timer = nil
+		}
+	}
+}
+
+// applyPeers rebuilds the consistent-hash ring and httpGetters for a new
+// peer snapshot, logging any peers added or removed relative to the
+// previous snapshot.
+func (p *HTTPPool) applyPeers(peers []string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+
+	added, removed := diffPeers(p.peerSet, peers)
+	for _, peer := range added {
+		p.Log("peer added: %s", peer)
+	}
+	for _, peer := range removed {
+		p.Log("peer removed: %s", peer)
+	}
+
 	p.peers = consistenthash.New(p.opts.Replicas, p.opts.HashFn)
 	p.peers.Add(peers...)
 	p.httpGetters = make(map[string]*httpGetter, len(peers))
 	for _, peer := range peers {
 		p.httpGetters[peer] = &httpGetter{
-			baseURL: peer + p.opts.BasePath,
-			decoder: DecoderFunc(p.opts.Codec.Decode),
+			baseURL:   peer + p.opts.BasePath,
+			codec:     p.opts.Codec,
+			transport: p.opts.Transport,
 		}
 	}
+	p.peerSet = peers
+}
+
+// diffPeers returns the entries of next missing from prev (added) and
+// the entries of prev missing from next (removed).
+func diffPeers(prev, next []string) (added, removed []string) {
+	prevSet := make(map[string]bool, len(prev))
+	for _, peer := range prev {
+		prevSet[peer] = true
+	}
+	nextSet := make(map[string]bool, len(next))
+	for _, peer := range next {
+		nextSet[peer] = true
+		if !prevSet[peer] {
+			added = append(added, peer)
+		}
+	}
+	for _, peer := range prev {
+		if !nextSet[peer] {
+			removed = append(removed, peer)
+		}
+	}
+	return added, removed
 }
 
 // PickPeer picks the peer associated with the key, return nil if the peer is self.
@@ -137,43 +343,122 @@ func (p *HTTPPool) PickPeer(key string) (PeerGetter, bool) {
 	return nil, false
 }
 
+// PickAllPeers returns a PeerGetter for every other peer in the pool,
+// excluding self; used to fan out Remove. The caller is expected to have
+// already evicted the key locally (see Group.Remove), so self has no
+// business being in this list too.
+func (p *HTTPPool) PickAllPeers() []PeerGetter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	peers := make([]PeerGetter, 0, len(p.httpGetters))
+	for peer, getter := range p.httpGetters {
+		if peer == p.self {
+			continue
+		}
+		peers = append(peers, getter)
+	}
+	return peers
+}
+
 var _ PeerPicker = (*HTTPPool)(nil)
+var _ PeerRemover = (*HTTPPool)(nil)
 
 // httpGetter implements PeerGetter.
 type httpGetter struct {
-	baseURL string  // peer's base url
-	decoder Decoder
+	baseURL string // peer's base url
+	codec   Codec  // negotiates the Accept header and decodes the response
+	// transport, if non-nil, supplies the http.RoundTripper used for
+	// requests to this peer, keyed off the request's context.
+	transport func(context.Context) http.RoundTripper
+}
+
+func (h *httpGetter) roundTripper(ctx context.Context) http.RoundTripper {
+	if h.transport != nil {
+		if rt := h.transport(ctx); rt != nil {
+			return rt
+		}
+	}
+	return http.DefaultTransport
 }
 
 // Get gets cache value from a peer using internal encoding/decoding mechanism through HTTP.
-func (h *httpGetter) Get(group string, key string) ([]byte, error) {
+func (h *httpGetter) Get(ctx context.Context, group string, key string) ([]byte, time.Time, error) {
 	u := fmt.Sprintf(
 		"%v%v/%v",
 		h.baseURL,
 		url.QueryEscape(group),
 		url.QueryEscape(key),
 	)
-	res, err := http.Get(u)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Accept", contentTypeForCodec(h.codec))
+
+	res, err := h.roundTripper(ctx).RoundTrip(req)
+	if err != nil {
+		return nil, time.Time{}, err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned: %v", res.Status)
+		return nil, time.Time{}, fmt.Errorf("server returned: %v", res.Status)
 	}
 
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response body: %v", err)
+		return nil, time.Time{}, fmt.Errorf("reading response body: %v", err)
 	}
 
-	view, err := h.decoder.Decode(body)
+	// The peer may have responded with a different codec than the one we
+	// asked for (e.g. an older peer); decode per the Content-Type it
+	// actually sent so mixed-codec deployments keep interoperating.
+	codec := h.codec
+	if ct := res.Header.Get("Content-Type"); ct != "" {
+		codec = codecForContentType(ct)
+	}
+
+	var (
+		view ByteView
+		meta ViewMeta
+	)
+	if md, ok := codec.(MetaDecoder); ok {
+		view, meta, err = md.DecodeWithMeta(body)
+	} else {
+		view, err = codec.Decode(body)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("decoding response body: %v", err)
+		return nil, time.Time{}, fmt.Errorf("decoding response body: %v", err)
 	}
 
-	return view.ByteSlice(), nil
+	return view.ByteSlice(), meta.Expire, nil
+}
+
+// Remove asks the peer to evict group/key from its local caches via HTTP
+// DELETE. It goes through the same Transport hook as Get, so Remove
+// doesn't silently skip whatever custom TLS/auth a peer requires.
+func (h *httpGetter) Remove(group string, key string) error {
+	u := fmt.Sprintf(
+		"%v%v/%v",
+		h.baseURL,
+		url.QueryEscape(group),
+		url.QueryEscape(key),
+	)
+	ctx := context.Background()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+	res, err := h.roundTripper(ctx).RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned: %v", res.Status)
+	}
+	return nil
 }
 
 var _ PeerGetter = (*httpGetter)(nil)
@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"container/list"
+	"time"
+)
+
+// Cache is a LRU cache. It is not safe for concurrent access.
+type Cache struct {
+	maxBytes int64
+	nbytes   int64
+	ll       *list.List
+	cache    map[string]*list.Element
+	// optional and executed when an entry is purged.
+	OnEvicted func(key string, value Value)
+}
+
+type entry struct {
+	key    string
+	value  Value
+	expire time.Time // zero value means the entry never expires
+}
+
+// Value use Len to count how many bytes it takes.
+type Value interface {
+	Len() int
+}
+
+// New is the Constructor of Cache.
+func New(maxBytes int64, onEvicted func(string, Value)) *Cache {
+	return &Cache{
+		maxBytes:  maxBytes,
+		ll:        list.New(),
+		cache:     make(map[string]*list.Element),
+		OnEvicted: onEvicted,
+	}
+}
+
+// Get looks up a key's value, evicting it first if it has expired.
+func (c *Cache) Get(key string) (value Value, ok bool) {
+	value, _, ok = c.GetWithExpire(key)
+	return
+}
+
+// GetWithExpire looks up a key's value and its absolute expiration time,
+// evicting it first if it has expired.
+func (c *Cache) GetWithExpire(key string) (value Value, expire time.Time, ok bool) {
+	if ele, ok := c.cache[key]; ok {
+		kv := ele.Value.(*entry)
+		if !kv.expire.IsZero() && time.Now().After(kv.expire) {
+			c.removeElement(ele)
+			return nil, time.Time{}, false
+		}
+		c.ll.MoveToFront(ele)
+		return kv.value, kv.expire, true
+	}
+	return
+}
+
+// RemoveOldest removes the oldest item.
+func (c *Cache) RemoveOldest() {
+	ele := c.ll.Back()
+	if ele != nil {
+		c.removeElement(ele)
+	}
+}
+
+// Remove removes a key from the cache, if present.
+func (c *Cache) Remove(key string) bool {
+	ele, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	c.removeElement(ele)
+	return true
+}
+
+func (c *Cache) removeElement(ele *list.Element) {
+	c.ll.Remove(ele)
+	kv := ele.Value.(*entry)
+	delete(c.cache, kv.key)
+	c.nbytes -= int64(len(kv.key)) + int64(kv.value.Len())
+	if c.OnEvicted != nil {
+		c.OnEvicted(kv.key, kv.value)
+	}
+}
+
+// Add adds a value to the cache. expire is the absolute time at which the
+// entry should be considered stale; the zero value means it never expires.
+func (c *Cache) Add(key string, value Value, expire time.Time) {
+	if ele, ok := c.cache[key]; ok {
+		c.ll.MoveToFront(ele)
+		kv := ele.Value.(*entry)
+		c.nbytes += int64(value.Len()) - int64(kv.value.Len())
+		kv.value = value
+		kv.expire = expire
+		return
+	}
+	ele := c.ll.PushFront(&entry{key: key, value: value, expire: expire})
+	c.cache[key] = ele
+	c.nbytes += int64(len(key)) + int64(value.Len())
+	for c.maxBytes != 0 && c.maxBytes < c.nbytes {
+		c.RemoveOldest()
+	}
+}
+
+// Len returns the number of cache entries.
+func (c *Cache) Len() int {
+	return c.ll.Len()
+}
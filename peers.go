@@ -1,5 +1,10 @@
 package yocache
 
+import (
+	"context"
+	"time"
+)
+
 // PeerPicker is the interface that must be implemented to locate
 // the peer that owns a specific key.
 type PeerPicker interface {
@@ -10,5 +15,25 @@ type PeerPicker interface {
 // PeerGetter is the interface that must be implemented by a peer to get
 // value owned by it.
 type PeerGetter interface {
-	Get(group string, key string) (value []byte, err error)
+	// Get fetches group/key from the peer. ctx carries deadlines,
+	// tracing, and auth that the transport may need to propagate.
+	// expire is the value's absolute expiration time on the owning
+	// peer, if the negotiated Codec carries that metadata; the zero
+	// value means unknown or never-expiring.
+	Get(ctx context.Context, group string, key string) (value []byte, expire time.Time, err error)
+
+	// Remove asks the peer to evict group/key from its local caches.
+	Remove(group string, key string) (err error)
+}
+
+// PeerRemover is an optional interface a PeerPicker may implement to
+// support fanning Remove out to every known peer, not just the one that
+// owns the key. Group.Remove fetches it via a type assertion so that
+// PeerPickers which don't track full membership (e.g. in tests) aren't
+// forced to implement it.
+type PeerRemover interface {
+	// PickAllPeers returns a PeerGetter for every known peer. An
+	// implementation may leave out this process's own peer, whose local
+	// caches Group.Remove has already cleared (HTTPPool does).
+	PickAllPeers() []PeerGetter
 }
@@ -0,0 +1,59 @@
+package yocache
+
+import (
+	"sync"
+	"time"
+)
+
+// qpsTracker tracks an approximate requests-per-minute rate per key, so
+// the owning peer can tell other peers how hot a key currently is (see
+// ViewMeta.MinuteQPS). Each key gets a single counter that resets every
+// minute, trading precision for a sliding window for a cheap fixed-size
+// window per key.
+type qpsTracker struct {
+	mu      sync.Mutex
+	windows map[string]*qpsWindow
+}
+
+type qpsWindow struct {
+	start time.Time
+	count int64
+}
+
+func newQPSTracker() *qpsTracker {
+	return &qpsTracker{windows: make(map[string]*qpsWindow)}
+}
+
+// recordHit counts one request for key in the current minute window,
+// starting a new window if the previous one has elapsed.
+func (t *qpsTracker) recordHit(key string) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.windows[key]
+	if !ok || now.Sub(w.start) >= time.Minute {
+		w = &qpsWindow{start: now}
+		t.windows[key] = w
+	}
+	w.count++
+}
+
+// rate returns key's requests-per-minute rate, extrapolated from the
+// current window's count if the window hasn't closed yet. It returns 0
+// for a key with no recorded hits.
+func (t *qpsTracker) rate(key string) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, ok := t.windows[key]
+	if !ok {
+		return 0
+	}
+	elapsed := time.Since(w.start)
+	if elapsed <= 0 {
+		return float64(w.count)
+	}
+	if elapsed > time.Minute {
+		elapsed = time.Minute
+	}
+	return float64(w.count) * time.Minute.Seconds() / elapsed.Seconds()
+}
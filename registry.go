@@ -0,0 +1,161 @@
+package yocache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Registry discovers a pool's peer set and watches it for changes, as an
+// alternative to calling HTTPPool.Set by hand whenever membership
+// changes (e.g. in an orchestrated environment where peers come and go).
+//
+// Watch returns a channel of complete peer-address snapshots, not
+// deltas: each value sent replaces the previous membership entirely. The
+// channel must be closed once ctx is done; Watch itself may also return
+// an error if the initial snapshot can't be obtained.
+type Registry interface {
+	Watch(ctx context.Context) (<-chan []string, error)
+}
+
+// StaticRegistry implements Registry over a fixed peer list that never
+// changes, for deployments that configure peers out of band (e.g. a
+// config file read once at startup) but still want to drive HTTPPool
+// through the same Watch-based code path.
+type StaticRegistry []string
+
+// Watch implements Registry.
+func (r StaticRegistry) Watch(ctx context.Context) (<-chan []string, error) {
+	ch := make(chan []string, 1)
+	ch <- append([]string(nil), r...)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
+
+// ChanRegistry implements Registry by relaying the snapshots it is sent
+// over a channel. It is meant for tests and for in-process membership
+// managers (e.g. a gossip protocol) that already produce []string
+// snapshots and just need to hand them to HTTPPool.
+type ChanRegistry chan []string
+
+// Watch implements Registry. It relays r through a goroutine that exits
+// and closes the returned channel once ctx is done, since r itself is
+// owned by the caller and Watch has no business closing it directly.
+func (r ChanRegistry) Watch(ctx context.Context) (<-chan []string, error) {
+	ch := make(chan []string)
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case peers, ok := <-r:
+				if !ok {
+					return
+				}
+				select {
+				case ch <- peers:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// dnsResolver is the subset of *net.Resolver used by DNSRegistry, so
+// tests can substitute a fake.
+type dnsResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (cname string, addrs []*net.SRV, err error)
+}
+
+// DNSRegistry discovers peers by periodically resolving a DNS SRV
+// record, the way a Kubernetes headless Service publishes the pods
+// backing it.
+type DNSRegistry struct {
+	// Service, Proto, and Name are passed to net.Resolver.LookupSRV, e.g.
+	// ("yocache", "tcp", "yocache.default.svc.cluster.local").
+	Service, Proto, Name string
+
+	// Scheme prefixes each resolved target to form a peer base URL, e.g.
+	// "http://". If blank, it defaults to "http://".
+	Scheme string
+
+	// Interval between re-resolutions. If zero, it defaults to 10s.
+	Interval time.Duration
+
+	// Resolver, if non-nil, is used instead of net.DefaultResolver.
+	Resolver dnsResolver
+}
+
+// Watch implements Registry. It resolves once synchronously, so Watch
+// returns an error if the record can't be found at all, then keeps
+// re-resolving every Interval until ctx is done. A re-resolution that
+// errors is logged and skipped rather than torn down, since DNS hiccups
+// are expected to be transient.
+func (r DNSRegistry) Watch(ctx context.Context) (<-chan []string, error) {
+	scheme := r.Scheme
+	if scheme == "" {
+		scheme = "http://"
+	}
+	interval := r.Interval
+	if interval == 0 {
+		interval = 10 * time.Second
+	}
+	resolver := r.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	resolve := func() ([]string, error) {
+		_, srvs, err := resolver.LookupSRV(ctx, r.Service, r.Proto, r.Name)
+		if err != nil {
+			return nil, err
+		}
+		peers := make([]string, len(srvs))
+		for i, srv := range srvs {
+			peers[i] = fmt.Sprintf("%s%s:%d", scheme, strings.TrimSuffix(srv.Target, "."), srv.Port)
+		}
+		sort.Strings(peers)
+		return peers, nil
+	}
+
+	initial, err := resolve()
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", r.Name, err)
+	}
+
+	ch := make(chan []string, 1)
+	ch <- initial
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				peers, err := resolve()
+				if err != nil {
+					log.Printf("[YoCache] re-resolving %s: %v", r.Name, err)
+					continue
+				}
+				select {
+				case ch <- peers:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,294 @@
+package yocache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"yocache/singleflight"
+)
+
+// Getter loads data for a key. ctx carries the deadline and metadata of
+// whatever request triggered the load, if any.
+type Getter interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// GetterFunc implements Getter with a function.
+type GetterFunc func(ctx context.Context, key string) ([]byte, error)
+
+// Get implements Getter interface function.
+func (f GetterFunc) Get(ctx context.Context, key string) ([]byte, error) {
+	return f(ctx, key)
+}
+
+// hotCacheRatio is the fraction of a Group's cacheBytes budget reserved
+// for hotCache, the rest going to mainCache. Mirrors groupcache's 1/8
+// split.
+const hotCacheRatio = 8
+
+// hotCacheProbability is the chance, expressed as 1-in-N, that a
+// successful peer fetch is also proactively replicated into hotCache.
+const hotCacheProbability = 10
+
+// Group is a cache namespace and associated data loaded spread over
+// one or more peers.
+type Group struct {
+	name   string
+	getter Getter
+	// mainCache holds keys this peer owns.
+	mainCache cache
+	// hotCache holds keys owned by a remote peer but cached locally
+	// anyway because they are being requested often, to cut down on
+	// cross-node RPCs for skewed workloads.
+	hotCache cache
+	peers    PeerPicker
+	// loader makes sure that each key is only fetched once, no matter
+	// how many concurrent callers there are.
+	loader *singleflight.Group
+	// removeGroup coalesces concurrent Remove calls for the same key into
+	// a single round of peer RPCs.
+	removeGroup *singleflight.Group
+	// qps tracks how often each key owned by this peer (i.e. served from
+	// mainCache) is being requested, for MinuteQPS.
+	qps *qpsTracker
+
+	stats Stats
+}
+
+// Stats holds counters of a Group's cache activity. All fields are
+// updated with sync/atomic and safe to read via Stats while the Group is
+// in use.
+type Stats struct {
+	Gets           int64 // any Get call, whether served from cache or not
+	CacheHits      int64 // Gets served from mainCache or hotCache
+	PeerLoads      int64 // successful fetches from a remote peer
+	PeerErrors     int64 // failed fetches from a remote peer
+	LocalLoads     int64 // loads served by this group's own Getter
+	LoadsDeduped   int64 // loads that actually ran, after singleflight
+	ServerRequests int64 // requests served to peers over HTTPPool
+}
+
+var (
+	mu     sync.RWMutex
+	groups = make(map[string]*Group)
+)
+
+// NewGroup creates a new instance of Group.
+func NewGroup(name string, cacheBytes int64, ttl time.Duration, getter Getter) *Group {
+	if getter == nil {
+		panic("nil Getter")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	hotBytes := cacheBytes / hotCacheRatio
+	g := &Group{
+		name:        name,
+		getter:      getter,
+		mainCache:   cache{cacheBytes: cacheBytes - hotBytes, ttl: ttl},
+		hotCache:    cache{cacheBytes: hotBytes, ttl: ttl},
+		loader:      &singleflight.Group{},
+		removeGroup: &singleflight.Group{},
+		qps:         newQPSTracker(),
+	}
+	groups[name] = g
+	return g
+}
+
+// GetGroup returns the named group previously created with NewGroup, or
+// nil if there's no such group.
+func GetGroup(name string) *Group {
+	mu.RLock()
+	defer mu.RUnlock()
+	return groups[name]
+}
+
+// Get value for a key from cache. ctx is propagated to the loader and, on
+// a cache miss routed to a peer, to that peer's PeerGetter.Get.
+func (g *Group) Get(ctx context.Context, key string) (ByteView, error) {
+	atomic.AddInt64(&g.stats.Gets, 1)
+	if key == "" {
+		return ByteView{}, fmt.Errorf("key is required")
+	}
+
+	if v, ok := g.lookupCache(key); ok {
+		atomic.AddInt64(&g.stats.CacheHits, 1)
+		return v, nil
+	}
+
+	return g.load(ctx, key)
+}
+
+// lookupCache checks mainCache, then hotCache.
+func (g *Group) lookupCache(key string) (ByteView, bool) {
+	if v, ok := g.mainCache.get(key); ok {
+		g.qps.recordHit(key)
+		return v, true
+	}
+	return g.hotCache.get(key)
+}
+
+// MinuteQPS returns key's approximate requests-per-minute rate as
+// observed by this peer while owning it (i.e. served from mainCache).
+// PeerGetter server implementations (see HTTPPool.ServeHTTP,
+// grpcpool.Server.Get) embed it in ViewMeta as a hint to the fetching
+// peer. The fetching side in this package does not act on it: load
+// replicates a peer-fetched key into hotCache at random, 1 in
+// hotCacheProbability, whatever its MinuteQPS.
+func (g *Group) MinuteQPS(key string) float64 {
+	return g.qps.rate(key)
+}
+
+// Stats returns a snapshot of this group's cache activity counters.
+func (g *Group) Stats() Stats {
+	return Stats{
+		Gets:           atomic.LoadInt64(&g.stats.Gets),
+		CacheHits:      atomic.LoadInt64(&g.stats.CacheHits),
+		PeerLoads:      atomic.LoadInt64(&g.stats.PeerLoads),
+		PeerErrors:     atomic.LoadInt64(&g.stats.PeerErrors),
+		LocalLoads:     atomic.LoadInt64(&g.stats.LocalLoads),
+		LoadsDeduped:   atomic.LoadInt64(&g.stats.LoadsDeduped),
+		ServerRequests: atomic.LoadInt64(&g.stats.ServerRequests),
+	}
+}
+
+// RegisterPeers registers a PeerPicker for choosing remote peers.
+func (g *Group) RegisterPeers(peers PeerPicker) {
+	if g.peers != nil {
+		panic("RegisterPeers called more than once")
+	}
+	g.peers = peers
+}
+
+// Remove purges key from this group's local caches and, if the registered
+// PeerPicker implements PeerRemover, calls PeerGetter.Remove (an HTTP
+// DELETE for HTTPPool) on every peer from PickAllPeers so stale copies
+// elsewhere are invalidated too. Concurrent
+// Remove calls for the same key coalesce into one round of peer RPCs.
+// Every peer is attempted regardless of earlier failures; the first error
+// encountered, if any, is returned once all peers have been contacted.
+func (g *Group) Remove(key string) error {
+	_, err := g.removeGroup.Do(key, func() (interface{}, error) {
+		g.localRemove(key)
+
+		if g.peers == nil {
+			return nil, nil
+		}
+		remover, ok := g.peers.(PeerRemover)
+		if !ok {
+			return nil, nil
+		}
+
+		peers := remover.PickAllPeers()
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			firstErr error
+		)
+		for _, peer := range peers {
+			wg.Add(1)
+			go func(peer PeerGetter) {
+				defer wg.Done()
+				if err := peer.Remove(g.name, key); err != nil {
+					log.Printf("[YoCache] failed to remove from peer: %v", err)
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}(peer)
+		}
+		wg.Wait()
+		return nil, firstErr
+	})
+	return err
+}
+
+// localRemove evicts key from this group's local caches only.
+func (g *Group) localRemove(key string) {
+	g.mainCache.remove(key)
+	g.hotCache.remove(key)
+}
+
+// LocalRemove evicts key from this group's local caches only, without
+// forwarding to peers. It is exported for alternative PeerGetter
+// transports (e.g. grpcpool) implementing the receiving side of Remove,
+// which must not re-trigger Remove's own peer fan-out.
+func (g *Group) LocalRemove(key string) {
+	g.localRemove(key)
+}
+
+// Expire returns the absolute expiration time of key in this group's
+// local caches, and whether the key was found there at all. It lets
+// alternative PeerGetter transports embed a peer's remaining TTL into
+// their own wire format, the way HTTPPool does via ProtoCodec.
+func (g *Group) Expire(key string) (expire time.Time, ok bool) {
+	if _, expire, ok = g.mainCache.getWithExpire(key); ok {
+		return expire, true
+	}
+	_, expire, ok = g.hotCache.getWithExpire(key)
+	return expire, ok
+}
+
+func (g *Group) load(ctx context.Context, key string) (value ByteView, err error) {
+	viewi, err := g.loader.Do(key, func() (interface{}, error) {
+		atomic.AddInt64(&g.stats.LoadsDeduped, 1)
+		if g.peers != nil {
+			if peer, ok := g.peers.PickPeer(key); ok {
+				var expire time.Time
+				if value, expire, err = g.getFromPeer(ctx, peer, key); err == nil {
+					atomic.AddInt64(&g.stats.PeerLoads, 1)
+					if rand.Intn(hotCacheProbability) == 0 {
+						g.populateHotCache(key, value, expire)
+					}
+					return value, nil
+				}
+				atomic.AddInt64(&g.stats.PeerErrors, 1)
+				log.Printf("[YoCache] failed to get from peer: %v", err)
+			}
+		}
+
+		return g.getLocally(ctx, key)
+	})
+
+	if err == nil {
+		return viewi.(ByteView), nil
+	}
+	return
+}
+
+func (g *Group) getLocally(ctx context.Context, key string) (ByteView, error) {
+	atomic.AddInt64(&g.stats.LocalLoads, 1)
+	bytes, err := g.getter.Get(ctx, key)
+	if err != nil {
+		return ByteView{}, err
+	}
+	value := ByteView{b: cloneBytes(bytes)}
+	g.populateCache(key, value)
+	g.qps.recordHit(key)
+	return value, nil
+}
+
+func (g *Group) getFromPeer(ctx context.Context, peer PeerGetter, key string) (ByteView, time.Time, error) {
+	bytes, expire, err := peer.Get(ctx, g.name, key)
+	if err != nil {
+		return ByteView{}, time.Time{}, err
+	}
+	return ByteView{b: bytes}, expire, nil
+}
+
+func (g *Group) populateCache(key string, value ByteView) {
+	g.mainCache.add(key, value)
+}
+
+// populateHotCache proactively replicates a key owned by a remote peer
+// so future Gets can be served locally. expire, if set, is the peer's
+// remaining TTL and is honored instead of resetting the local TTL clock.
+func (g *Group) populateHotCache(key string, value ByteView, expire time.Time) {
+	g.hotCache.addWithExpire(key, value, expire)
+}
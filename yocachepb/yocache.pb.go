@@ -0,0 +1,150 @@
+// Hand-written wire encoding for the messages declared in yocache.proto.
+// There is no protoc step wired into this repo, so this file is NOT
+// generated — it implements the protobuf wire format (varints, tags,
+// length-delimited fields) by hand and must be kept in sync with
+// yocache.proto manually. Do not regenerate it with protoc-gen-go; that
+// would discard the hand-rolled Marshal/Unmarshal below.
+
+package yocachepb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+// Envelope is the wire message exchanged between HTTPPool peers when the
+// proto codec is negotiated. See yocache.proto for field documentation.
+type Envelope struct {
+	Value           []byte
+	ExpireUnixNano  int64
+	MinuteQps       float64
+	ContentEncoding string
+}
+
+func (e *Envelope) Reset()         { *e = Envelope{} }
+func (e *Envelope) String() string { return fmt.Sprintf("%+v", *e) }
+func (*Envelope) ProtoMessage()    {}
+
+// Marshal encodes e using the protobuf wire format.
+func (e *Envelope) Marshal() ([]byte, error) {
+	var buf []byte
+	if len(e.Value) > 0 {
+		buf = appendTag(buf, 1, wireBytes)
+		buf = appendVarint(buf, uint64(len(e.Value)))
+		buf = append(buf, e.Value...)
+	}
+	if e.ExpireUnixNano != 0 {
+		buf = appendTag(buf, 2, wireVarint)
+		buf = appendVarint(buf, uint64(e.ExpireUnixNano))
+	}
+	if e.MinuteQps != 0 {
+		buf = appendTag(buf, 3, wireFixed64)
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(e.MinuteQps))
+		buf = append(buf, b[:]...)
+	}
+	if e.ContentEncoding != "" {
+		buf = appendTag(buf, 4, wireBytes)
+		buf = appendVarint(buf, uint64(len(e.ContentEncoding)))
+		buf = append(buf, e.ContentEncoding...)
+	}
+	return buf, nil
+}
+
+// Unmarshal decodes e from the protobuf wire format, overwriting any
+// prior contents.
+func (e *Envelope) Unmarshal(data []byte) error {
+	*e = Envelope{}
+	for len(data) > 0 {
+		field, wireType, n, err := consumeTag(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+
+		switch wireType {
+		case wireVarint:
+			v, n, err := consumeVarint(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+			if field == 2 {
+				e.ExpireUnixNano = int64(v)
+			}
+		case wireFixed64:
+			if len(data) < 8 {
+				return fmt.Errorf("yocachepb: truncated fixed64 field %d", field)
+			}
+			v := binary.LittleEndian.Uint64(data[:8])
+			data = data[8:]
+			if field == 3 {
+				e.MinuteQps = math.Float64frombits(v)
+			}
+		case wireBytes:
+			length, n, err := consumeVarint(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+			if uint64(len(data)) < length {
+				return fmt.Errorf("yocachepb: truncated length-delimited field %d", field)
+			}
+			v := data[:length]
+			data = data[length:]
+			switch field {
+			case 1:
+				e.Value = append([]byte(nil), v...)
+			case 4:
+				e.ContentEncoding = string(v)
+			}
+		default:
+			return fmt.Errorf("yocachepb: unsupported wire type %d for field %d", wireType, field)
+		}
+	}
+	return nil
+}
+
+func appendTag(buf []byte, field int, wireType uint64) []byte {
+	return appendVarint(buf, uint64(field)<<3|wireType)
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func consumeVarint(data []byte) (v uint64, n int, err error) {
+	for shift := uint(0); ; shift += 7 {
+		if n >= len(data) {
+			return 0, 0, fmt.Errorf("yocachepb: truncated varint")
+		}
+		b := data[n]
+		n++
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, n, nil
+		}
+		if shift >= 63 {
+			return 0, 0, fmt.Errorf("yocachepb: varint overflow")
+		}
+	}
+}
+
+func consumeTag(data []byte) (field int, wireType uint64, n int, err error) {
+	v, n, err := consumeVarint(data)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return int(v >> 3), v & 0x7, n, nil
+}